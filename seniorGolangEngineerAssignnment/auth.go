@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Role ranks the two roles recognised by requireRole: viewer can read,
+// admin can read and write. Higher rank satisfies a lower role requirement.
+var roleRank = map[string]int{
+	"viewer": 1,
+	"admin":  2,
+}
+
+const defaultTokenTTL = 1 * time.Hour
+
+type contextKey string
+
+// claimsContextKey is the key authMiddleware stores the parsed *Claims
+// under in the request context.
+const claimsContextKey contextKey = "claims"
+
+// Claims are the JWT claims minted by POST /login and checked by
+// authMiddleware/requireRole on every subsequent request.
+type Claims struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// jwtSigningSecret and authUserStore hold the auth configuration loaded by
+// loadAuthConfig at startup (see main()); GenerateToken, ParseToken and
+// LoginHandler all read from these rather than the environment directly.
+var (
+	jwtSigningSecret []byte
+	authUserStore    map[string]authUser
+)
+
+// authUser is one entry in the credential store POST /login checks
+// against.
+type authUser struct {
+	Password string
+	Role     string
+}
+
+// loadAuthConfig reads the HS256 signing secret from JWT_SECRET and the
+// credential store from AUTH_USERS (a comma separated list of
+// "username:password:role" triples, e.g. "alice:wonderland:admin"). Both
+// must be set explicitly: silently falling back to a well-known secret or
+// admin/admin account would let anyone who has read this source code forge
+// admin tokens against a misconfigured deploy that forgot to set them. Set
+// ALLOW_INSECURE_DEV_AUTH=true to opt into a fixed dev secret and an
+// admin/admin account for local development instead.
+func loadAuthConfig() ([]byte, map[string]authUser, error) {
+	secret := os.Getenv("JWT_SECRET")
+	users := parseUsers(os.Getenv("AUTH_USERS"))
+
+	if secret != "" && len(users) > 0 {
+		return []byte(secret), users, nil
+	}
+
+	if os.Getenv("ALLOW_INSECURE_DEV_AUTH") != "true" {
+		return nil, nil, fmt.Errorf("JWT_SECRET and AUTH_USERS must both be set (or ALLOW_INSECURE_DEV_AUTH=true for local development only)")
+	}
+
+	logger.Printf("Warning: JWT_SECRET/AUTH_USERS not set, falling back to an insecure development secret and/or admin/admin account (ALLOW_INSECURE_DEV_AUTH=true)")
+	if secret == "" {
+		secret = "dev-only-insecure-secret"
+	}
+	if len(users) == 0 {
+		users = map[string]authUser{"admin": {Password: "admin", Role: "admin"}}
+	}
+	return []byte(secret), users, nil
+}
+
+// parseUsers parses the AUTH_USERS format described on loadAuthConfig;
+// malformed entries are skipped.
+func parseUsers(raw string) map[string]authUser {
+	users := make(map[string]authUser)
+	for _, entry := range strings.Split(raw, ",") {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		users[parts[0]] = authUser{Password: parts[1], Role: parts[2]}
+	}
+	return users
+}
+
+// GenerateToken signs a new HS256 token asserting username/role, valid for
+// defaultTokenTTL.
+func GenerateToken(username, role string) (string, error) {
+	claims := Claims{
+		Username: username,
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(defaultTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSigningSecret)
+}
+
+// ParseToken validates tokenStr's signature and expiry and returns its
+// claims.
+func ParseToken(tokenStr string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return jwtSigningSecret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// authMiddleware requires a valid "Authorization: Bearer <token>" header on
+// every request it wraps and attaches the parsed claims to the request
+// context for requireRole (and handlers) to read.
+func authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		tokenStr := strings.TrimPrefix(header, "Bearer ")
+		if tokenStr == "" || tokenStr == header {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := ParseToken(tokenStr)
+		if err != nil {
+			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requireRole wraps next so it only runs when the request's claims (set by
+// authMiddleware, which must run first) satisfy minRole or better.
+func requireRole(minRole string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(claimsContextKey).(*Claims)
+		if !ok {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		if roleRank[claims.Role] < roleRank[minRole] {
+			http.Error(w, "insufficient role", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// loginRequest is the POST /login request body.
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginHandler checks the submitted credentials against the AUTH_USERS
+// store and, on success, returns a signed JWT asserting the matched role.
+func LoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	user, ok := authUserStore[req.Username]
+	if !ok || user.Password != req.Password {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := GenerateToken(req.Username, user.Role)
+	if err != nil {
+		logger.Printf("Error: failed to sign token for %q: %v", req.Username, err)
+		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
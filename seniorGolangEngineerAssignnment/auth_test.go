@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// setupTestAuth installs a fixed signing secret and admin/admin credential
+// store for the duration of a test. In production these come from
+// loadAuthConfig (JWT_SECRET/AUTH_USERS, see auth.go); tests set them
+// directly rather than relying on environment variables.
+func setupTestAuth(t *testing.T) {
+	t.Helper()
+	jwtSigningSecret = []byte("test-signing-secret")
+	authUserStore = map[string]authUser{"admin": {Password: "admin", Role: "admin"}}
+}
+
+// mustMintToken signs a token for the given role, failing the test if
+// signing errors. Used by tests that need to exercise authenticated
+// routes.
+func mustMintToken(t *testing.T, role string) string {
+	t.Helper()
+	token, err := GenerateToken("test-"+role, role)
+	if err != nil {
+		t.Fatalf("minting %s token: %v", role, err)
+	}
+	return token
+}
+
+// newAuthTestRouter wires /employees behind the same authMiddleware/
+// requireRole chain main() uses, so tests here exercise the real
+// authorization wiring rather than calling handlers directly.
+func newAuthTestRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/login", LoginHandler).Methods("POST")
+	router.Handle("/employees", authMiddleware(requireRole("admin", http.HandlerFunc(CreateEmployee)))).Methods("POST")
+	router.Handle("/employees", authMiddleware(requireRole("viewer", http.HandlerFunc(ListEmployees)))).Methods("GET")
+	router.Use(loggingMiddleware)
+	return router
+}
+
+func TestLoginReturnsTokenForValidCredentials(t *testing.T) {
+	setupTestAuth(t)
+	router := newAuthTestRouter()
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	body, _ := json.Marshal(loginRequest{Username: "admin", Password: "admin"})
+	resp, err := http.Post(ts.URL+"/login", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("POST /login: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, resp.StatusCode)
+	}
+
+	var got map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decoding login response: %v", err)
+	}
+	if got["token"] == "" {
+		t.Fatal("expected a non-empty token")
+	}
+
+	claims, err := ParseToken(got["token"])
+	if err != nil {
+		t.Fatalf("parsing minted token: %v", err)
+	}
+	if claims.Role != "admin" {
+		t.Errorf("expected role %q, got %q", "admin", claims.Role)
+	}
+}
+
+func TestLoginRejectsInvalidCredentials(t *testing.T) {
+	setupTestAuth(t)
+	router := newAuthTestRouter()
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	body, _ := json.Marshal(loginRequest{Username: "admin", Password: "wrong"})
+	resp, err := http.Post(ts.URL+"/login", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("POST /login: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status %v, got %v", http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestCreateEmployeeRequiresBearerToken(t *testing.T) {
+	setupTestAuth(t)
+	store = NewMemoryStore()
+	router := newAuthTestRouter()
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	body, _ := json.Marshal(Employee{Name: "No Token", Position: "Engineer", Salary: 1})
+	resp, err := http.Post(ts.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("POST /employees: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status %v, got %v", http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestCreateEmployeeRejectsViewerRole(t *testing.T) {
+	setupTestAuth(t)
+	store = NewMemoryStore()
+	router := newAuthTestRouter()
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	body, _ := json.Marshal(Employee{Name: "Viewer Write", Position: "Engineer", Salary: 1})
+	req, _ := http.NewRequest("POST", ts.URL+"/employees", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+mustMintToken(t, "viewer"))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /employees: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status %v, got %v", http.StatusForbidden, resp.StatusCode)
+	}
+}
+
+// clearAuthEnv unsets everything loadAuthConfig reads, restoring each on
+// cleanup so other tests (which never touch these) aren't affected by
+// ordering.
+func clearAuthEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"JWT_SECRET", "AUTH_USERS", "ALLOW_INSECURE_DEV_AUTH"} {
+		old, had := os.LookupEnv(key)
+		os.Unsetenv(key)
+		t.Cleanup(func() {
+			if had {
+				os.Setenv(key, old)
+			}
+		})
+	}
+}
+
+func TestLoadAuthConfigFailsClosedWhenUnconfigured(t *testing.T) {
+	clearAuthEnv(t)
+
+	if _, _, err := loadAuthConfig(); err == nil {
+		t.Fatal("expected an error when JWT_SECRET/AUTH_USERS are unset and ALLOW_INSECURE_DEV_AUTH is not set")
+	}
+}
+
+func TestLoadAuthConfigAllowsExplicitInsecureDevOptIn(t *testing.T) {
+	clearAuthEnv(t)
+	os.Setenv("ALLOW_INSECURE_DEV_AUTH", "true")
+
+	secret, users, err := loadAuthConfig()
+	if err != nil {
+		t.Fatalf("loadAuthConfig: %v", err)
+	}
+	if len(secret) == 0 {
+		t.Error("expected a non-empty dev secret")
+	}
+	if users["admin"].Role != "admin" {
+		t.Errorf("expected a fallback admin account, got %+v", users)
+	}
+}
+
+func TestCreateEmployeeAllowsAdminRole(t *testing.T) {
+	setupTestAuth(t)
+	store = NewMemoryStore()
+	router := newAuthTestRouter()
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	body, _ := json.Marshal(Employee{Name: "Admin Write", Position: "Engineer", Salary: 50000})
+	req, _ := http.NewRequest("POST", ts.URL+"/employees", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+mustMintToken(t, "admin"))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /employees: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status %v, got %v", http.StatusOK, resp.StatusCode)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// newStoreFromEnv builds the Store backend selected by the STORE_DRIVER
+// environment variable. Supported values are "memory" (default), "bolt" and
+// "sql".
+//
+//   - STORE_DRIVER=bolt reads BOLT_PATH (default "employees.db").
+//   - STORE_DRIVER=sql reads SQL_DRIVER ("postgres" or "sqlite", default
+//     "sqlite") and SQL_DSN (default "employees.sqlite" for sqlite).
+func newStoreFromEnv() (Store, error) {
+	switch os.Getenv("STORE_DRIVER") {
+	case "bolt":
+		path := os.Getenv("BOLT_PATH")
+		if path == "" {
+			path = "employees.db"
+		}
+		return NewBoltStore(path)
+
+	case "sql":
+		driver := os.Getenv("SQL_DRIVER")
+		if driver == "" {
+			driver = "sqlite"
+		}
+
+		dsn := os.Getenv("SQL_DSN")
+		if dsn == "" && driver == "sqlite" {
+			dsn = "employees.sqlite"
+		}
+
+		db, err := sql.Open(driver, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("opening sql database: %w", err)
+		}
+		return NewSQLStore(db, driver)
+
+	case "", "memory":
+		return NewMemoryStore(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown STORE_DRIVER %q", os.Getenv("STORE_DRIVER"))
+	}
+}
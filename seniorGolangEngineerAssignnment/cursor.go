@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// cursorToken is the opaque value handed back as next_cursor. It pins both
+// the last row's ID and its value on the primary sort key, so the next page
+// can be located by searching for a position rather than counting an
+// offset — unlike page/pageSize, deleting a row never shifts what a cursor
+// points at.
+type cursorToken struct {
+	LastID  int         `json:"last_id"`
+	SortKey interface{} `json:"sort_key"`
+}
+
+// encodeCursor base64-encodes tok for use as the next_cursor response field.
+func encodeCursor(tok cursorToken) string {
+	data, err := json.Marshal(tok)
+	if err != nil {
+		// cursorToken only ever holds int/string/float64, so this can't fail.
+		panic(fmt.Sprintf("encoding cursor: %v", err))
+	}
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reverses encodeCursor, returning an error for malformed
+// client-supplied ?cursor= values.
+func decodeCursor(raw string) (*cursorToken, error) {
+	data, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	var tok cursorToken
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, fmt.Errorf("invalid cursor payload: %w", err)
+	}
+	return &tok, nil
+}
+
+// primarySortValue returns emp's value on the primary sort field (the first
+// entry of fields, or "id" when sorting wasn't customized), typed so it
+// round-trips through JSON as a string or number.
+func primarySortValue(emp *Employee, fields []SortField) interface{} {
+	field := "id"
+	if len(fields) > 0 {
+		field = fields[0].Field
+	}
+	switch field {
+	case "name":
+		return emp.Name
+	case "position":
+		return emp.Position
+	case "salary":
+		return emp.Salary
+	default:
+		return float64(emp.ID)
+	}
+}
+
+// cursorStartIndex returns the index of the first employee in sorted (which
+// must already be ordered per fields) that comes strictly after cur. Rows
+// are compared as the (sort value, ID) pair sortEmployees itself orders by
+// — not just the sort value — so that ties on a non-unique sort key (e.g.
+// ?sort=position) are still ordered deterministically and a row tying the
+// cursor's value is only skipped once, even if the employee the cursor was
+// minted from has since been deleted.
+func cursorStartIndex(sorted []*Employee, fields []SortField, cur *cursorToken) int {
+	desc := len(fields) > 0 && fields[0].Desc
+
+	for i, emp := range sorted {
+		value := primarySortValue(emp, fields)
+		if sortValueEqual(value, cur.SortKey) {
+			if emp.ID > cur.LastID {
+				return i
+			}
+			continue
+		}
+		if sortValueAfter(value, cur.SortKey, desc) {
+			return i
+		}
+	}
+	return len(sorted)
+}
+
+// sortValueEqual reports whether value and cursorValue are the same
+// string/float64 sort value; a type mismatch (a tampered cursor) is not
+// equal.
+func sortValueEqual(value, cursorValue interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		cv, ok := cursorValue.(string)
+		return ok && v == cv
+	case float64:
+		cv, ok := cursorValue.(float64)
+		return ok && v == cv
+	default:
+		return false
+	}
+}
+
+// sortValueAfter reports whether value sorts strictly after cursorValue,
+// honoring desc. Both must be the string/float64 shapes primarySortValue and
+// JSON decoding produce; a type mismatch (a tampered cursor) is treated as
+// "not after" so the request degrades to an empty page rather than panicking.
+func sortValueAfter(value, cursorValue interface{}, desc bool) bool {
+	switch v := value.(type) {
+	case string:
+		cv, ok := cursorValue.(string)
+		if !ok {
+			return false
+		}
+		if desc {
+			return v < cv
+		}
+		return v > cv
+	case float64:
+		cv, ok := cursorValue.(float64)
+		if !ok {
+			return false
+		}
+		if desc {
+			return v < cv
+		}
+		return v > cv
+	default:
+		return false
+	}
+}
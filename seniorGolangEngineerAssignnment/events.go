@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// EventType identifies what happened to an employee for the SSE change
+// feed below.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// eventRingSize bounds how many recent events GET /employees/events?since=
+// can replay; older events are simply unavailable to late subscribers.
+const eventRingSize = 256
+
+// Event is one entry on the employee change feed. ID is the feed's own
+// monotonic sequence number (not the employee's ID) and is what ?since=
+// compares against.
+type Event struct {
+	ID         int
+	Type       EventType
+	EmployeeID int
+	Employee   *Employee // nil for EventDeleted
+}
+
+// eventHub is a small in-process pub/sub: CRUD handlers publish into it
+// under mutex, and each GET /employees/events connection holds its own
+// subscriber channel fed by a fan-out loop in publish.
+type eventHub struct {
+	mutex       sync.Mutex
+	subscribers map[chan Event]struct{}
+	ring        []Event
+	nextID      int
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[chan Event]struct{})}
+}
+
+// employeeEvents is the process-wide change feed the CRUD handlers publish
+// to and the SSE handler below subscribes to.
+var employeeEvents = newEventHub()
+
+// publish assigns evt the next sequence number, appends it to the replay
+// ring, and fans it out to every current subscriber. A subscriber whose
+// buffered channel is full is skipped rather than blocking the publisher.
+func (h *eventHub) publish(evt Event) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.nextID++
+	evt.ID = h.nextID
+
+	h.ring = append(h.ring, evt)
+	if len(h.ring) > eventRingSize {
+		h.ring = h.ring[len(h.ring)-eventRingSize:]
+	}
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
+
+// subscribeSince registers a new subscriber channel and snapshots the
+// replay backlog after id under a single critical section, so no event
+// published concurrently can land in both: publish either lands in replay
+// (and the subscriber isn't registered yet to also receive it over ch) or
+// is fanned out over ch (and is too new to be in replay). Doing this as two
+// separate locked operations would let such an event be delivered twice.
+func (h *eventHub) subscribeSince(id int) (ch chan Event, replay []Event, unsubscribe func()) {
+	ch = make(chan Event, 16)
+
+	h.mutex.Lock()
+	h.subscribers[ch] = struct{}{}
+	for _, evt := range h.ring {
+		if evt.ID > id {
+			replay = append(replay, evt)
+		}
+	}
+	h.mutex.Unlock()
+
+	return ch, replay, func() {
+		h.mutex.Lock()
+		delete(h.subscribers, ch)
+		h.mutex.Unlock()
+	}
+}
+
+// EmployeeEventsStream serves GET /employees/events as Server-Sent Events:
+// it replays anything after ?since=<id> from the ring buffer, then, unless
+// ?follow=false, keeps the connection open and streams new events as they
+// are published.
+func EmployeeEventsStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	since, _ := strconv.Atoi(r.URL.Query().Get("since"))
+	follow := r.URL.Query().Get("follow") != "false"
+
+	ch, replay, unsubscribe := employeeEvents.subscribeSince(since)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, evt := range replay {
+		if err := writeSSEEvent(w, evt); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	if !follow {
+		return
+	}
+
+	for {
+		select {
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeSSEEvent(w, evt); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeSSEEvent writes evt in the standard SSE wire format:
+// "id: <seq>\nevent: <type>\ndata: <json>\n\n".
+func writeSSEEvent(w http.ResponseWriter, evt Event) error {
+	var payload interface{} = evt.Employee
+	if evt.Type == EventDeleted {
+		payload = map[string]int{"id": evt.EmployeeID}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evt.ID, evt.Type, data)
+	return err
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func newEventsTestRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/employees", CreateEmployee).Methods("POST")
+	router.HandleFunc("/employees/events", EmployeeEventsStream).Methods("GET")
+	router.Use(loggingMiddleware)
+	return router
+}
+
+// readSSEEvent blocks until it can read one complete "event: ...\ndata:
+// ...\n\n" block from r, or returns an error.
+func readSSEEvent(r *bufio.Reader) (event, data string, err error) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "" && event != "":
+			return event, data, nil
+		}
+	}
+}
+
+func TestEmployeeEventsStream(t *testing.T) {
+	store = NewMemoryStore()
+	employeeEvents = newEventHub()
+
+	router := newEventsTestRouter()
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	streamReq, err := http.NewRequest("GET", ts.URL+"/employees/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamResp, err := http.DefaultClient.Do(streamReq)
+	if err != nil {
+		t.Fatalf("opening event stream: %v", err)
+	}
+	defer streamResp.Body.Close()
+
+	if ct := streamResp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected Content-Type text/event-stream, got %q", ct)
+	}
+
+	newEmp := Employee{Name: "Stream Test", Position: "Engineer", Salary: 95000}
+	body, _ := json.Marshal(newEmp)
+	if _, err := http.Post(ts.URL+"/employees", "application/json", bytes.NewBuffer(body)); err != nil {
+		t.Fatalf("POST /employees: %v", err)
+	}
+
+	type result struct {
+		event, data string
+		err         error
+	}
+	done := make(chan result, 1)
+	go func() {
+		event, data, err := readSSEEvent(bufio.NewReader(streamResp.Body))
+		done <- result{event, data, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("reading SSE event: %v", res.err)
+		}
+		if res.event != string(EventCreated) {
+			t.Errorf("expected event %q, got %q", EventCreated, res.event)
+		}
+		var got Employee
+		if err := json.Unmarshal([]byte(res.data), &got); err != nil {
+			t.Fatalf("unmarshaling event data: %v", err)
+		}
+		if got.Name != newEmp.Name {
+			t.Errorf("expected streamed employee name %q, got %q", newEmp.Name, got.Name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for SSE event")
+	}
+}
+
+func TestEmployeeEventsStreamReplaysSince(t *testing.T) {
+	employeeEvents = newEventHub()
+
+	first := employeeEvents.nextID + 1
+	employeeEvents.publish(Event{Type: EventCreated, EmployeeID: 1, Employee: &Employee{ID: 1, Name: "A"}})
+	employeeEvents.publish(Event{Type: EventCreated, EmployeeID: 2, Employee: &Employee{ID: 2, Name: "B"}})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/employees/events", EmployeeEventsStream).Methods("GET")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/employees/events?since=" + strconv.Itoa(first) + "&follow=false")
+	if err != nil {
+		t.Fatalf("GET /employees/events: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	event, data, err := readSSEEvent(reader)
+	if err != nil {
+		t.Fatalf("reading replayed event: %v", err)
+	}
+	if event != string(EventCreated) {
+		t.Errorf("expected event %q, got %q", EventCreated, event)
+	}
+	var got Employee
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("unmarshaling replayed event: %v", err)
+	}
+	if got.Name != "B" {
+		t.Errorf("expected replay to skip the already-seen event and return %q, got %q", "B", got.Name)
+	}
+}
+
+// TestEventHubSubscribeSinceNoDuplicateDelivery guards against the replay
+// snapshot and subscription happening as two separate critical sections: if
+// they did, an event published in between could land in both the replay
+// slice and the newly registered channel. subscribeSince must do both
+// atomically so every concurrently published event is delivered exactly
+// once per subscriber.
+func TestEventHubSubscribeSinceNoDuplicateDelivery(t *testing.T) {
+	h := newEventHub()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		id := 0
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				id++
+				h.publish(Event{Type: EventCreated, EmployeeID: id})
+			}
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		ch, replay, unsubscribe := h.subscribeSince(0)
+
+		seen := make(map[int]bool, len(replay))
+		for _, evt := range replay {
+			if seen[evt.ID] {
+				t.Fatalf("event %d present twice in a single replay", evt.ID)
+			}
+			seen[evt.ID] = true
+		}
+
+	drain:
+		for {
+			select {
+			case evt := <-ch:
+				if seen[evt.ID] {
+					t.Fatalf("event %d delivered via both replay and channel", evt.ID)
+				}
+				seen[evt.ID] = true
+			default:
+				break drain
+			}
+		}
+
+		unsubscribe()
+	}
+
+	close(stop)
+	wg.Wait()
+}
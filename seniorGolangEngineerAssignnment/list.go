@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// listResponse is the body returned for cursor-paginated requests; page/
+// pageSize requests keep returning a bare JSON array for backward
+// compatibility.
+type listResponse struct {
+	Data       []*Employee `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	Total      int         `json:"total"`
+}
+
+// parseListFilter reads the name/position/minSalary/maxSalary query params
+// into a ListFilter. Unparsable salary bounds are treated as unset rather
+// than rejected, consistent with page/pageSize's existing leniency.
+func parseListFilter(query url.Values) ListFilter {
+	minSalary, _ := strconv.ParseFloat(query.Get("minSalary"), 64)
+	maxSalary, _ := strconv.ParseFloat(query.Get("maxSalary"), 64)
+	return ListFilter{
+		Name:      query.Get("name"),
+		Position:  query.Get("position"),
+		MinSalary: minSalary,
+		MaxSalary: maxSalary,
+	}
+}
+
+// paginate slices a fully materialized, already-sorted list into the
+// offset/limit window, used when ?sort= forces an in-memory sort ahead of
+// page/pageSize pagination.
+func paginate(all []*Employee, offset, limit int) []*Employee {
+	if offset >= len(all) {
+		return []*Employee{}
+	}
+	end := offset + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[offset:end]
+}
+
+// listEmployeesCursor serves the ?cursor= pagination mode: it fetches every
+// row matching filter, sorts it, locates the cursor's position and returns
+// the next page plus a fresh next_cursor, all wrapped in a listResponse.
+func listEmployeesCursor(w http.ResponseWriter, filter ListFilter, sortFields []SortField, rawCursor string, pageSize int) {
+	all, total, err := store.List(0, ListAll, filter)
+	if err != nil {
+		logger.Printf("Error: failed to list employees: %v", err)
+		http.Error(w, "Failed to list employees", http.StatusInternalServerError)
+		return
+	}
+	sortEmployees(all, sortFields)
+
+	start := 0
+	if rawCursor != "" {
+		cur, err := decodeCursor(rawCursor)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		start = cursorStartIndex(all, sortFields, cur)
+	}
+
+	page := paginate(all, start, pageSize)
+
+	resp := listResponse{Data: page, Total: total}
+	if start+len(page) < len(all) && len(page) > 0 {
+		last := page[len(page)-1]
+		resp.NextCursor = encodeCursor(cursorToken{LastID: last.ID, SortKey: primarySortValue(last, sortFields)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -2,21 +2,28 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
-	"sync"
+	"syscall"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-var logger *log.Logger
+var (
+	logger  *log.Logger
+	logFile *os.File
+)
 
 func init() {
 	// Create a log file or use os.Stdout for console logging
-	logFile, err := os.OpenFile("server.log", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	var err error
+	logFile, err = os.OpenFile("server.log", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
 	if err != nil {
 		log.Fatalf("Error opening log file: %v", err)
 	}
@@ -41,14 +48,12 @@ type Employee struct {
 	Salary   float64 `json:"salary"`
 }
 
-// In-memory employee database with a mutex for concurrency
-var (
-	employees = make(map[int]*Employee)
-	mutex     sync.RWMutex
-	lastID    = 0
-)
+// store is the persistence backend the handlers below talk to. It is
+// assigned once in main() from configuration/environment variables; see
+// config.go.
+var store Store
 
-// CRUD operations with concurrency safety
+// CRUD operations, delegated to the configured Store implementation.
 
 func CreateEmployee(w http.ResponseWriter, r *http.Request) {
 	var emp Employee
@@ -57,14 +62,23 @@ func CreateEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mutex.Lock()
-	lastID++
-	emp.ID = lastID
-	employees[emp.ID] = &emp
-	mutex.Unlock()
+	if verr := validateEmployee(&emp); verr != nil {
+		writeValidationError(w, verr)
+		return
+	}
+
+	created, err := store.Create(&emp)
+	if err != nil {
+		logger.Printf("Error: failed to create employee: %v", err)
+		http.Error(w, "Failed to create employee", http.StatusInternalServerError)
+		return
+	}
+	lastEmployeeID.Set(float64(created.ID))
+	refreshEmployeeGauges()
+	employeeEvents.publish(Event{Type: EventCreated, EmployeeID: created.ID, Employee: created})
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(emp)
+	json.NewEncoder(w).Encode(created)
 }
 
 func GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
@@ -76,16 +90,18 @@ func GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid employee ID", http.StatusBadRequest)
 		return
 	}
-	mutex.RLock() // Read lock to allow concurrent reads
-	defer mutex.RUnlock()
-
-	emp, ok := employees[id]
 
-	if !ok {
+	emp, err := store.Get(id)
+	if errors.Is(err, ErrNotFound) {
 		logger.Printf("Error: Employee not found (ID: %d)", id)
 		http.Error(w, "Employee not found", http.StatusNotFound)
 		return
 	}
+	if err != nil {
+		logger.Printf("Error: failed to get employee (ID: %d): %v", id, err)
+		http.Error(w, "Failed to get employee", http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(emp)
@@ -112,20 +128,28 @@ func UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	updatedEmp.ID = id // Ensure the ID is not changed
 
-	mutex.Lock() // Exclusive lock to prevent concurrent modifications
-	defer mutex.Unlock()
+	if verr := validateEmployee(&updatedEmp); verr != nil {
+		writeValidationError(w, verr)
+		return
+	}
 
-	if _, ok := employees[id]; !ok {
+	updated, err := store.Update(id, &updatedEmp)
+	if errors.Is(err, ErrNotFound) {
 		logger.Printf("Error: Employee not found (ID: %d)", id)
 		http.Error(w, "Employee not found", http.StatusNotFound)
 		return
 	}
+	if err != nil {
+		logger.Printf("Error: failed to update employee (ID: %d): %v", id, err)
+		http.Error(w, "Failed to update employee", http.StatusInternalServerError)
+		return
+	}
+
+	employeeEvents.publish(Event{Type: EventUpdated, EmployeeID: updated.ID, Employee: updated})
 
-	employees[id] = &updatedEmp
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(updatedEmp)
+	json.NewEncoder(w).Encode(updated)
 }
 
 func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
@@ -137,81 +161,120 @@ func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid employee ID", http.StatusBadRequest)
 		return
 	}
-	mutex.Lock() // Exclusive lock to prevent concurrent modifications
-	defer mutex.Unlock()
 
-	if _, ok := employees[id]; !ok {
+	err = store.Delete(id)
+	if errors.Is(err, ErrNotFound) {
 		logger.Printf("Error: Employee not found (ID: %d)", id)
 		http.Error(w, "Employee not found", http.StatusNotFound)
 		return
 	}
+	if err != nil {
+		logger.Printf("Error: failed to delete employee (ID: %d): %v", id, err)
+		http.Error(w, "Failed to delete employee", http.StatusInternalServerError)
+		return
+	}
+	refreshEmployeeGauges()
+	employeeEvents.publish(Event{Type: EventDeleted, EmployeeID: id})
 
-	delete(employees, id)
 	w.WriteHeader(http.StatusNoContent) // 204 No Content on successful delete
 }
 
-// RESTful API with pagination
+// RESTful API with pagination. Supports three query param groups, which can
+// be combined: name/position/minSalary/maxSalary filter the result set,
+// sort=field,-field controls ordering, and either page/pageSize (default,
+// returns a bare JSON array) or cursor (returns a {data,next_cursor,total}
+// envelope) controls which rows come back.
 func ListEmployees(w http.ResponseWriter, r *http.Request) {
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	if page < 1 {
-		page = 1
-	}
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	query := r.URL.Query()
+	filter := parseListFilter(query)
+	sortFields := parseSortParam(query.Get("sort"))
+
+	pageSize, _ := strconv.Atoi(query.Get("pageSize"))
 	if pageSize < 1 {
 		pageSize = 10
 	}
 
-	mutex.RLock()
-	defer mutex.RUnlock()
-
-	employeeList := make([]*Employee, 0, len(employees)) // Create a slice to hold employees in a specific order
-	for _, emp := range employees {
-		employeeList = append(employeeList, emp)
-	}
-
-	totalEmployees := len(employeeList)
-	startIdx := (page - 1) * pageSize
-
-	if startIdx >= totalEmployees {
-		results := []*Employee{}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(results)
+	if _, hasCursor := query["cursor"]; hasCursor {
+		listEmployeesCursor(w, filter, sortFields, query.Get("cursor"), pageSize)
 		return
 	}
 
-	endIdx := startIdx + pageSize
-	if endIdx > totalEmployees {
-		endIdx = totalEmployees
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	offset := (page - 1) * pageSize
+
+	var results []*Employee
+	var err error
+	if len(sortFields) == 0 {
+		results, _, err = store.List(offset, pageSize, filter)
+	} else {
+		var all []*Employee
+		if all, _, err = store.List(0, ListAll, filter); err == nil {
+			sortEmployees(all, sortFields)
+			results = paginate(all, offset, pageSize)
+		}
+	}
+	if err != nil {
+		logger.Printf("Error: failed to list employees: %v", err)
+		http.Error(w, "Failed to list employees", http.StatusInternalServerError)
+		return
 	}
-
-	results := employeeList[startIdx:endIdx]
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(results)
 }
 
 func main() {
+	s, err := newStoreFromEnv()
+	if err != nil {
+		logger.Fatalf("Error initializing store: %v", err)
+	}
+	store = s
+	refreshEmployeeGauges()
+
+	secret, users, err := loadAuthConfig()
+	if err != nil {
+		logger.Fatalf("Error initializing auth: %v", err)
+	}
+	jwtSigningSecret = secret
+	authUserStore = users
 
 	r := mux.NewRouter()
 
-	// Define routes with specified HTTP methods
-	r.HandleFunc("/employees", CreateEmployee).Methods("POST")
-	r.HandleFunc("/employees/{id}", GetEmployeeByID).Methods("GET")
-	r.HandleFunc("/employees/{id}", UpdateEmployee).Methods("PUT")
-	r.HandleFunc("/employees/{id}", DeleteEmployee).Methods("DELETE")
-	r.HandleFunc("/employees", ListEmployees).Methods("GET")
+	// Define routes with specified HTTP methods. /employees/events is
+	// registered ahead of /employees/{id} so it isn't swallowed by the
+	// wildcard ID route. Writes require role "admin", reads require
+	// "viewer"; /login and /metrics are unauthenticated.
+	r.HandleFunc("/login", LoginHandler).Methods("POST")
+	r.Handle("/employees", authMiddleware(requireRole("admin", http.HandlerFunc(CreateEmployee)))).Methods("POST")
+	r.Handle("/employees/events", authMiddleware(requireRole("viewer", http.HandlerFunc(EmployeeEventsStream)))).Methods("GET")
+	r.Handle("/employees/{id}", authMiddleware(requireRole("viewer", http.HandlerFunc(GetEmployeeByID)))).Methods("GET")
+	r.Handle("/employees/{id}", authMiddleware(requireRole("admin", http.HandlerFunc(UpdateEmployee)))).Methods("PUT")
+	r.Handle("/employees/{id}", authMiddleware(requireRole("admin", http.HandlerFunc(DeleteEmployee)))).Methods("DELETE")
+	r.Handle("/employees", authMiddleware(requireRole("viewer", http.HandlerFunc(ListEmployees)))).Methods("GET")
+	r.Handle("/metrics", promhttp.Handler())
 
 	r.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, "Employee Management API")
 	})
 
-	// Use the logging middleware for all routes
+	// Use the logging and metrics middleware for all routes
 	r.Use(loggingMiddleware)
+	r.Use(metricsMiddleware)
 
-	port := ":4000"
-	logger.Printf("Server starting on port %s", port)
-	err := http.ListenAndServe(port, r)
-	if err != nil {
-		logger.Fatalf("Error starting server: %v", err)
+	srv := &http.Server{Addr: ":4000", Handler: r}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	logger.Printf("Server starting on port %s", srv.Addr)
+	if err := runServer(srv, sigCh, drainTimeoutFromEnv()); err != nil {
+		logger.Printf("Error running server: %v", err)
+	}
+
+	if err := logFile.Close(); err != nil {
+		log.Printf("Error closing log file: %v", err)
 	}
 }
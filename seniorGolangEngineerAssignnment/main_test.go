@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,8 @@ import (
 )
 
 func TestCreateEmployee(t *testing.T) {
+	store = NewMemoryStore()
+
 	// Test data
 	newEmp := Employee{
 		Name:     "Alice Johnson",
@@ -62,10 +65,7 @@ func TestCreateEmployee(t *testing.T) {
 
 func TestGetEmployeeByID(t *testing.T) {
 	// Setup
-	mutex.Lock()
-	employees = make(map[int]*Employee)
-	lastID = 0
-	mutex.Unlock()
+	store = NewMemoryStore()
 
 	newEmp := Employee{
 		Name:     "John Doe",
@@ -113,10 +113,7 @@ func TestGetEmployeeByID(t *testing.T) {
 
 func TestUpdateEmployee(t *testing.T) {
 
-	mutex.Lock()
-	employees = make(map[int]*Employee)
-	lastID = 0
-	mutex.Unlock()
+	store = NewMemoryStore()
 
 	newEmp := Employee{
 		Name:     "John Doe",
@@ -174,10 +171,7 @@ func TestUpdateEmployee(t *testing.T) {
 
 func TestDeleteEmployee(t *testing.T) {
 
-	mutex.Lock()
-	employees = make(map[int]*Employee)
-	lastID = 0
-	mutex.Unlock()
+	store = NewMemoryStore()
 
 	newEmp := Employee{
 		Name:     "John Doe",
@@ -213,76 +207,224 @@ func TestDeleteEmployee(t *testing.T) {
 	}
 
 	// Check if employee is actually deleted
-	mutex.RLock()
-	_, exists := employees[newEmp.ID]
-	mutex.RUnlock()
-	if exists {
+	if _, err := store.Get(newEmp.ID); !errors.Is(err, ErrNotFound) {
 		t.Error("Employee was not deleted")
 	}
 }
 
-// Helper function to create an employee and add to the map
+// Helper function to create an employee via the store and mirror the
+// assigned ID back onto emp.
 func CreateEmployeeHelper(t *testing.T, emp *Employee) {
-	mutex.Lock()
-	defer mutex.Unlock()
-	lastID++
-	emp.ID = lastID
-	employees[emp.ID] = emp
+	created, err := store.Create(emp)
+	if err != nil {
+		t.Fatalf("failed to seed employee: %v", err)
+	}
+	*emp = *created
 }
 
 func TestListEmployeesPagination(t *testing.T) {
 
-	// Test cases
+	// Test cases: page/pageSize, optional filter/sort query params, and the
+	// expected employee count and first-result name (empty to skip that
+	// check). Covers the original offset pagination plus the filtering and
+	// sorting added on top of it.
 	testCases := []struct {
-		page, pageSize, expectedCount int
+		name          string
+		query         string
+		expectedCount int
+		firstName     string
 	}{
-		{1, 10, 10},
-		{2, 10, 10},
-		{3, 10, 5},  // Last page
-		{1, 25, 25}, // All on one page
+		{"page 1 of 10", "page=1&pageSize=10", 10, ""},
+		{"page 2 of 10", "page=2&pageSize=10", 10, ""},
+		{"last page", "page=3&pageSize=10", 5, ""},
+		{"all on one page", "page=1&pageSize=25", 25, ""},
+		{"filter by position", "pageSize=25&position=Manager", 1, "Manager 0"},
+		{"filter by min salary", "pageSize=25&minSalary=60500", 1, ""},
+		{"filter by max salary", "pageSize=25&maxSalary=59500", 0, ""},
+		{"sort by name descending", "pageSize=1&sort=-name", 1, "Manager 0"},
+		{"sort by salary descending", "pageSize=1&sort=-salary", 1, "Manager 0"},
 	}
 
 	for _, tc := range testCases {
-		// Reset employee data before each test case
-		mutex.Lock()
-		employees = make(map[int]*Employee)
-		lastID = 0
-		mutex.Unlock()
-
-		// Setup: Populate employees
-		for i := 0; i < 25; i++ {
-			newEmp := Employee{
-				Name:     fmt.Sprintf("Employee %d", i),
-				Position: "Position",
-				Salary:   60000.0,
+		t.Run(tc.name, func(t *testing.T) {
+			// Reset employee data before each test case
+			store = NewMemoryStore()
+
+			// Setup: Populate employees
+			for i := 0; i < 24; i++ {
+				newEmp := Employee{
+					Name:     fmt.Sprintf("Employee %d", i),
+					Position: "Position",
+					Salary:   60000.0,
+				}
+				if _, err := store.Create(&newEmp); err != nil {
+					t.Fatalf("failed to seed employee: %v", err)
+				}
+			}
+			if _, err := store.Create(&Employee{Name: "Manager 0", Position: "Manager", Salary: 60500.0}); err != nil {
+				t.Fatalf("failed to seed employee: %v", err)
+			}
+
+			// Prepare request
+			req := httptest.NewRequest("GET", fmt.Sprintf("/employees?%s", tc.query), nil)
+			w := httptest.NewRecorder()
+
+			// Execute
+			ListEmployees(w, req)
+
+			// Validate response
+			if w.Code != http.StatusOK {
+				t.Errorf("Expected status %v, got %v", http.StatusOK, w.Code)
+			}
+
+			var listedEmps []*Employee
+			if err := json.Unmarshal(w.Body.Bytes(), &listedEmps); err != nil {
+				t.Fatal("Failed to unmarshal response:", err)
+			}
+
+			if len(listedEmps) != tc.expectedCount {
+				t.Errorf("Expected %d employees, got %d (query: %s)", tc.expectedCount, len(listedEmps), tc.query)
+			}
+			if tc.firstName != "" && len(listedEmps) > 0 && listedEmps[0].Name != tc.firstName {
+				t.Errorf("Expected first result %q, got %q (query: %s)", tc.firstName, listedEmps[0].Name, tc.query)
 			}
+		})
+	}
+}
 
-			mutex.Lock()
-			lastID++
-			newEmp.ID = lastID
-			employees[newEmp.ID] = &newEmp
-			mutex.Unlock()
+// TestListEmployeesCursorPagination walks the ?cursor= mode end to end,
+// including the case the offset scheme gets wrong: deleting a lower-ID row
+// between pages must not skip or duplicate any remaining employee.
+func TestListEmployeesCursorPagination(t *testing.T) {
+	store = NewMemoryStore()
+
+	ids := make([]int, 0, 5)
+	for i := 0; i < 5; i++ {
+		emp := Employee{Name: fmt.Sprintf("Employee %d", i), Position: "Position", Salary: 60000.0}
+		created, err := store.Create(&emp)
+		if err != nil {
+			t.Fatalf("failed to seed employee: %v", err)
 		}
+		ids = append(ids, created.ID)
+	}
 
-		// Prepare request
-		req := httptest.NewRequest("GET", fmt.Sprintf("/employees?page=%d&pageSize=%d", tc.page, tc.pageSize), nil)
+	fetchPage := func(cursor string) listResponse {
+		t.Helper()
+		req := httptest.NewRequest("GET", fmt.Sprintf("/employees?pageSize=2&cursor=%s", cursor), nil)
 		w := httptest.NewRecorder()
-
-		// Execute
 		ListEmployees(w, req)
-
-		// Validate response
 		if w.Code != http.StatusOK {
-			t.Errorf("Expected status %v, got %v", http.StatusOK, w.Code)
+			t.Fatalf("Expected status %v, got %v", http.StatusOK, w.Code)
+		}
+		var resp listResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
+		return resp
+	}
 
-		var listedEmps []*Employee
-		if err := json.Unmarshal(w.Body.Bytes(), &listedEmps); err != nil {
-			t.Fatal("Failed to unmarshal response:", err)
+	first := fetchPage("")
+	if len(first.Data) != 2 || first.Total != 5 || first.NextCursor == "" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+
+	// Delete the employee at the start of what would be the next page; with
+	// the old offset scheme this would silently skip an employee.
+	if err := store.Delete(ids[2]); err != nil {
+		t.Fatalf("failed to delete employee: %v", err)
+	}
+
+	seen := map[int]bool{first.Data[0].ID: true, first.Data[1].ID: true}
+	cursor := first.NextCursor
+	for cursor != "" {
+		page := fetchPage(cursor)
+		for _, emp := range page.Data {
+			if seen[emp.ID] {
+				t.Errorf("employee %d returned more than once", emp.ID)
+			}
+			seen[emp.ID] = true
 		}
+		cursor = page.NextCursor
+	}
 
-		if len(listedEmps) != tc.expectedCount {
-			t.Errorf("Expected %d employees, got %d (page: %d, pageSize: %d)", tc.expectedCount, len(listedEmps), tc.page, tc.pageSize)
+	if len(seen) != 4 {
+		t.Errorf("expected to see all 4 remaining employees exactly once, saw %d", len(seen))
+	}
+	if seen[ids[2]] {
+		t.Errorf("deleted employee %d should not appear in any page", ids[2])
+	}
+}
+
+// TestListEmployeesCursorPaginationNonUniqueSort covers cursoring by a
+// non-unique field (?sort=position): three employees tie on "eng". Deleting
+// the one the cursor was minted from must only drop that row, not every
+// other row sharing its sort value.
+func TestListEmployeesCursorPaginationNonUniqueSort(t *testing.T) {
+	store = NewMemoryStore()
+
+	positions := []string{"eng", "eng", "eng", "ops"}
+	ids := make([]int, 0, len(positions))
+	for i, pos := range positions {
+		emp := Employee{Name: fmt.Sprintf("Employee %d", i), Position: pos, Salary: 60000.0}
+		created, err := store.Create(&emp)
+		if err != nil {
+			t.Fatalf("failed to seed employee: %v", err)
 		}
+		ids = append(ids, created.ID)
+	}
+
+	fetchPage := func(cursor string) listResponse {
+		t.Helper()
+		req := httptest.NewRequest("GET", fmt.Sprintf("/employees?pageSize=1&sort=position&cursor=%s", cursor), nil)
+		w := httptest.NewRecorder()
+		ListEmployees(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %v, got %v", http.StatusOK, w.Code)
+		}
+		var resp listResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return resp
+	}
+
+	first := fetchPage("")
+	if len(first.Data) != 1 || first.Data[0].ID != ids[0] || first.NextCursor == "" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+
+	second := fetchPage(first.NextCursor)
+	if len(second.Data) != 1 || second.Data[0].ID != ids[1] {
+		t.Fatalf("unexpected second page: %+v", second)
+	}
+
+	// Delete the row the second page's cursor was minted from; it ties
+	// ids[2] on "eng", so a cursor that only compares sort value (not the ID
+	// tiebreaker) would skip ids[2] as well.
+	if err := store.Delete(ids[1]); err != nil {
+		t.Fatalf("failed to delete employee: %v", err)
+	}
+
+	seen := map[int]bool{ids[0]: true, ids[1]: true}
+	cursor := second.NextCursor
+	for cursor != "" {
+		page := fetchPage(cursor)
+		for _, emp := range page.Data {
+			if seen[emp.ID] {
+				t.Errorf("employee %d returned more than once", emp.ID)
+			}
+			seen[emp.ID] = true
+		}
+		cursor = page.NextCursor
+	}
+
+	if !seen[ids[2]] {
+		t.Errorf("employee %d (tied with deleted cursor row on position) was skipped", ids[2])
+	}
+	if !seen[ids[3]] {
+		t.Errorf("employee %d was skipped", ids[3])
+	}
+	if len(seen) != 4 {
+		t.Errorf("expected to see all 4 employees exactly once, saw %d", len(seen))
 	}
 }
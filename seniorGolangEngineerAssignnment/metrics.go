@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "employee_api_requests_total",
+			Help: "Total HTTP requests processed, labeled by route, method and status.",
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "employee_api_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, labeled by route and method.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method"},
+	)
+
+	employeeCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "employee_api_employees_total",
+		Help: "Current number of employees in the store.",
+	})
+
+	lastEmployeeID = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "employee_api_last_employee_id",
+		Help: "The most recently assigned employee ID.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(requestsTotal, requestDuration, employeeCount, lastEmployeeID)
+}
+
+// statusRecorder wraps http.ResponseWriter to capture the status code
+// written so metricsMiddleware can label requestsTotal/requestDuration with
+// it; http handlers that never call WriteHeader default to 200, same as
+// net/http itself.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush lets statusRecorder satisfy http.Flusher when the wrapped
+// ResponseWriter does, so streaming handlers (e.g. EmployeeEventsStream)
+// keep working when routed through metricsMiddleware.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// metricsMiddleware records request counts and latencies for every route.
+// It sits alongside loggingMiddleware in the middleware chain.
+func metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		route := routeTemplate(r)
+		requestsTotal.WithLabelValues(route, r.Method, strconv.Itoa(rec.status)).Inc()
+		requestDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// routeTemplate returns the matched mux route's path template (e.g.
+// "/employees/{id}") so metrics aren't cardinality-bombed by raw IDs.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return r.URL.Path
+}
+
+// refreshEmployeeGauges re-reads the employee count from the store. It is
+// called after every mutating CRUD operation and once at startup.
+func refreshEmployeeGauges() {
+	if store == nil {
+		return
+	}
+	_, total, err := store.List(0, 0, ListFilter{})
+	if err != nil {
+		logger.Printf("Error: failed to refresh employee gauges: %v", err)
+		return
+	}
+	employeeCount.Set(float64(total))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newInstrumentedTestRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/employees", CreateEmployee).Methods("POST")
+	router.HandleFunc("/employees/{id}", GetEmployeeByID).Methods("GET")
+	router.HandleFunc("/employees/{id}", UpdateEmployee).Methods("PUT")
+	router.HandleFunc("/employees/{id}", DeleteEmployee).Methods("DELETE")
+	router.HandleFunc("/employees", ListEmployees).Methods("GET")
+	router.Handle("/metrics", promhttp.Handler())
+	router.Use(loggingMiddleware)
+	router.Use(metricsMiddleware)
+	return router
+}
+
+// TestMetricsEndpointReflectsCRUDTraffic asserts counter deltas rather than
+// fixed absolute values: requestsTotal is a package-level CounterVec that is
+// never reset between tests (or between -count=N repeats of this one), so
+// asserting an absolute "...} 1" only happened to pass when this was the
+// sole test driving metricsMiddleware.
+func TestMetricsEndpointReflectsCRUDTraffic(t *testing.T) {
+	store = NewMemoryStore()
+
+	router := newInstrumentedTestRouter()
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	createCounter := requestsTotal.WithLabelValues("/employees", "POST", "200")
+	getCounter := requestsTotal.WithLabelValues("/employees/{id}", "GET", "200")
+	updateCounter := requestsTotal.WithLabelValues("/employees/{id}", "PUT", "200")
+	deleteCounter := requestsTotal.WithLabelValues("/employees/{id}", "DELETE", "204")
+	createBefore := testutil.ToFloat64(createCounter)
+	getBefore := testutil.ToFloat64(getCounter)
+	updateBefore := testutil.ToFloat64(updateCounter)
+	deleteBefore := testutil.ToFloat64(deleteCounter)
+
+	newEmp := Employee{Name: "Metrics Test", Position: "Engineer", Salary: 77000}
+	body, _ := json.Marshal(newEmp)
+
+	resp, err := http.Post(ts.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("POST /employees: %v", err)
+	}
+	var created Employee
+	json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+
+	if _, err := http.Get(fmt.Sprintf("%s/employees/%d", ts.URL, created.ID)); err != nil {
+		t.Fatalf("GET /employees/{id}: %v", err)
+	}
+
+	updatedEmp := Employee{Name: "Metrics Test", Position: "Senior Engineer", Salary: 88000}
+	updateBody, _ := json.Marshal(updatedEmp)
+	updateReq, _ := http.NewRequest("PUT", fmt.Sprintf("%s/employees/%d", ts.URL, created.ID), bytes.NewBuffer(updateBody))
+	updateReq.Header.Set("Content-Type", "application/json")
+	updateResp, err := http.DefaultClient.Do(updateReq)
+	if err != nil {
+		t.Fatalf("PUT /employees/{id}: %v", err)
+	}
+	updateResp.Body.Close()
+
+	deleteReq, _ := http.NewRequest("DELETE", fmt.Sprintf("%s/employees/%d", ts.URL, created.ID), nil)
+	deleteResp, err := http.DefaultClient.Do(deleteReq)
+	if err != nil {
+		t.Fatalf("DELETE /employees/{id}: %v", err)
+	}
+	deleteResp.Body.Close()
+
+	if got := testutil.ToFloat64(createCounter) - createBefore; got != 1 {
+		t.Errorf("employee_api_requests_total{method=POST,route=/employees,status=200} increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(getCounter) - getBefore; got != 1 {
+		t.Errorf("employee_api_requests_total{method=GET,route=/employees/{id},status=200} increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(updateCounter) - updateBefore; got != 1 {
+		t.Errorf("employee_api_requests_total{method=PUT,route=/employees/{id},status=200} increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(deleteCounter) - deleteBefore; got != 1 {
+		t.Errorf("employee_api_requests_total{method=DELETE,route=/employees/{id},status=204} increased by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(employeeCount); got != 0 {
+		t.Errorf("employee_api_employees_total = %v, want 0", got)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultDrainTimeout = 10 * time.Second
+
+// drainTimeoutFromEnv reads DRAIN_TIMEOUT_SECONDS, falling back to
+// defaultDrainTimeout when unset or invalid.
+func drainTimeoutFromEnv() time.Duration {
+	secs, err := strconv.Atoi(os.Getenv("DRAIN_TIMEOUT_SECONDS"))
+	if err != nil || secs <= 0 {
+		return defaultDrainTimeout
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// runServer starts srv in the background, blocks until a signal arrives on
+// sigCh, then gives in-flight requests up to drainTimeout to finish via
+// srv.Shutdown before returning. New connections are refused as soon as the
+// signal is received. It returns any error ListenAndServe or Shutdown
+// reported, ignoring the expected http.ErrServerClosed.
+func runServer(srv *http.Server, sigCh <-chan os.Signal, drainTimeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-sigCh:
+		logger.Printf("Shutdown signal received, draining in-flight requests (timeout %s)", drainTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return err
+	}
+	return <-serveErr
+}
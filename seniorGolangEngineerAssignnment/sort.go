@@ -0,0 +1,74 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// SortField is one key of a "?sort=name,-salary" query parameter: Field is
+// the employee attribute to order by and Desc flips its direction.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+var sortableFields = map[string]bool{
+	"id":       true,
+	"name":     true,
+	"position": true,
+	"salary":   true,
+}
+
+// parseSortParam turns "name,-salary" into [{Field:"name"}, {Field:"salary",Desc:true}],
+// silently dropping keys that aren't sortable so a typo can't 500 the request.
+func parseSortParam(raw string) []SortField {
+	if raw == "" {
+		return nil
+	}
+
+	var fields []SortField
+	for _, key := range strings.Split(raw, ",") {
+		key = strings.TrimSpace(key)
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+		if !sortableFields[key] {
+			continue
+		}
+		fields = append(fields, SortField{Field: key, Desc: desc})
+	}
+	return fields
+}
+
+// sortEmployees orders emps in place according to fields, always appending
+// an ascending-by-ID tiebreaker so the order is deterministic (and therefore
+// safe to cursor-paginate over).
+func sortEmployees(emps []*Employee, fields []SortField) {
+	sort.SliceStable(emps, func(i, j int) bool {
+		for _, f := range fields {
+			less, equal := compareEmployees(emps[i], emps[j], f.Field)
+			if equal {
+				continue
+			}
+			if f.Desc {
+				return !less
+			}
+			return less
+		}
+		return emps[i].ID < emps[j].ID
+	})
+}
+
+// compareEmployees reports whether a orders before b on the given field, and
+// whether they are equal on it.
+func compareEmployees(a, b *Employee, field string) (less, equal bool) {
+	switch field {
+	case "name":
+		return a.Name < b.Name, a.Name == b.Name
+	case "position":
+		return a.Position < b.Position, a.Position == b.Position
+	case "salary":
+		return a.Salary < b.Salary, a.Salary == b.Salary
+	default: // "id"
+		return a.ID < b.ID, a.ID == b.ID
+	}
+}
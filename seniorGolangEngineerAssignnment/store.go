@@ -0,0 +1,37 @@
+package main
+
+import "errors"
+
+// ErrNotFound is returned by Store implementations when an employee with the
+// requested ID does not exist.
+var ErrNotFound = errors.New("employee not found")
+
+// ListFilter narrows down the result set returned by Store.List. Zero values
+// mean "no filter on this field".
+type ListFilter struct {
+	Name      string
+	Position  string
+	MinSalary float64
+	MaxSalary float64
+}
+
+// ListAll can be passed as List's limit to fetch every matching row instead
+// of a bounded page. Used by the HTTP handler when it needs the full result
+// set to sort or cursor-paginate in memory.
+const ListAll = -1
+
+// Store is the persistence interface the HTTP handlers talk to. Concrete
+// implementations live in store_memory.go, store_bolt.go and store_sql.go;
+// the backend actually used at runtime is chosen in config.go from
+// configuration/environment variables.
+//
+// List always returns results ordered by ascending ID; callers that need a
+// different order (see ListEmployees' ?sort= support) re-sort the returned
+// slice themselves.
+type Store interface {
+	Create(emp *Employee) (*Employee, error)
+	Get(id int) (*Employee, error)
+	Update(id int, emp *Employee) (*Employee, error)
+	Delete(id int) error
+	List(offset, limit int, filter ListFilter) (results []*Employee, total int, err error)
+}
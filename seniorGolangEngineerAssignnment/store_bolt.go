@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var employeesBucket = []byte("employees")
+
+// BoltStore is a Store backed by a single BoltDB file. Employees are stored
+// as JSON values keyed by their big-endian-encoded ID so that bucket
+// iteration naturally yields ascending ID order.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) the BoltDB file at path and
+// ensures the employees bucket exists.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(employeesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func boltKey(id int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(id))
+	return key
+}
+
+func (s *BoltStore) Create(emp *Employee) (*Employee, error) {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(employeesBucket)
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		emp.ID = int(id)
+
+		data, err := json.Marshal(emp)
+		if err != nil {
+			return err
+		}
+		return b.Put(boltKey(emp.ID), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return emp, nil
+}
+
+func (s *BoltStore) Get(id int) (*Employee, error) {
+	var emp Employee
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(employeesBucket).Get(boltKey(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &emp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emp, nil
+}
+
+func (s *BoltStore) Update(id int, emp *Employee) (*Employee, error) {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(employeesBucket)
+		if b.Get(boltKey(id)) == nil {
+			return ErrNotFound
+		}
+		emp.ID = id
+		data, err := json.Marshal(emp)
+		if err != nil {
+			return err
+		}
+		return b.Put(boltKey(id), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return emp, nil
+}
+
+func (s *BoltStore) Delete(id int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(employeesBucket)
+		if b.Get(boltKey(id)) == nil {
+			return ErrNotFound
+		}
+		return b.Delete(boltKey(id))
+	})
+}
+
+func (s *BoltStore) List(offset, limit int, filter ListFilter) ([]*Employee, int, error) {
+	var matched []*Employee
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(employeesBucket).ForEach(func(_, data []byte) error {
+			var emp Employee
+			if err := json.Unmarshal(data, &emp); err != nil {
+				return err
+			}
+			if matchesFilter(&emp, filter) {
+				matched = append(matched, &emp)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Bucket.ForEach already yields keys (and therefore IDs) in ascending
+	// order, so no extra sort is needed here.
+	total := len(matched)
+	if limit == ListAll {
+		return matched, total, nil
+	}
+	if offset >= total {
+		return []*Employee{}, total, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return matched[offset:end], total, nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// storeFactories enumerates every Store backend the conformance suite below
+// runs against. Add a backend here, not a new copy of TestStoreConformance.
+func storeFactories(t *testing.T) map[string]Store {
+	t.Helper()
+
+	boltStore, err := NewBoltStore(filepath.Join(t.TempDir(), "employees.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore: %v", err)
+	}
+	t.Cleanup(func() { boltStore.Close() })
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "employees.sqlite"))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	sqlStore, err := NewSQLStore(db, "sqlite")
+	if err != nil {
+		t.Fatalf("NewSQLStore: %v", err)
+	}
+
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"bolt":   boltStore,
+		"sql":    sqlStore,
+	}
+}
+
+// TestStoreConformance runs the same behavioral assertions against every
+// Store implementation so new backends can't silently diverge from the
+// contract documented on the Store interface.
+func TestStoreConformance(t *testing.T) {
+	for name, store := range storeFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			testStoreCRUD(t, store)
+		})
+		t.Run(name+"/list", func(t *testing.T) {
+			testStoreList(t, store)
+		})
+	}
+}
+
+func testStoreCRUD(t *testing.T, store Store) {
+	t.Helper()
+
+	created, err := store.Create(&Employee{Name: "Alice", Position: "Engineer", Salary: 90000})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 {
+		t.Fatalf("Create: expected non-zero ID, got %d", created.ID)
+	}
+
+	got, err := store.Get(created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if *got != *created {
+		t.Errorf("Get: got %+v, want %+v", got, created)
+	}
+
+	updated, err := store.Update(created.ID, &Employee{Name: "Alice B.", Position: "Senior Engineer", Salary: 110000})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.ID != created.ID || updated.Name != "Alice B." {
+		t.Errorf("Update: got %+v", updated)
+	}
+
+	if err := store.Delete(created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: got err %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete of missing ID: got err %v, want ErrNotFound", err)
+	}
+	if _, err := store.Update(created.ID, &Employee{Name: "Ghost"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update of missing ID: got err %v, want ErrNotFound", err)
+	}
+}
+
+func testStoreList(t *testing.T, store Store) {
+	t.Helper()
+
+	for i := 0; i < 5; i++ {
+		if _, err := store.Create(&Employee{Name: "Bulk", Position: "Tester", Salary: 50000}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	page, total, err := store.List(0, 3, ListFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 5 {
+		t.Errorf("List: total = %d, want 5", total)
+	}
+	if len(page) != 3 {
+		t.Errorf("List: len(page) = %d, want 3", len(page))
+	}
+
+	rest, total, err := store.List(3, 3, ListFilter{})
+	if err != nil {
+		t.Fatalf("List (second page): %v", err)
+	}
+	if total != 5 {
+		t.Errorf("List (second page): total = %d, want 5", total)
+	}
+	if len(rest) != 2 {
+		t.Errorf("List (second page): len(rest) = %d, want 2", len(rest))
+	}
+
+	filtered, _, err := store.List(0, 10, ListFilter{Name: "no-such-employee"})
+	if err != nil {
+		t.Fatalf("List (filtered): %v", err)
+	}
+	if len(filtered) != 0 {
+		t.Errorf("List (filtered): len(filtered) = %d, want 0", len(filtered))
+	}
+
+	caseDiffering, _, err := store.List(0, 10, ListFilter{Name: "bulk", Position: "TESTER"})
+	if err != nil {
+		t.Fatalf("List (case-differing filter): %v", err)
+	}
+	if len(caseDiffering) != 5 {
+		t.Errorf("List (case-differing filter): len = %d, want 5 (filters must be case-insensitive)", len(caseDiffering))
+	}
+}
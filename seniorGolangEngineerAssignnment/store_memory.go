@@ -0,0 +1,114 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is an in-memory Store backed by a map and a mutex. It is the
+// default backend and what the test suite exercises most, since it needs no
+// external dependencies.
+type MemoryStore struct {
+	mutex     sync.RWMutex
+	employees map[int]*Employee
+	lastID    int
+}
+
+// NewMemoryStore returns an empty MemoryStore ready for use.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{employees: make(map[int]*Employee)}
+}
+
+func (s *MemoryStore) Create(emp *Employee) (*Employee, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lastID++
+	emp.ID = s.lastID
+	stored := *emp
+	s.employees[emp.ID] = &stored
+	return &stored, nil
+}
+
+func (s *MemoryStore) Get(id int) (*Employee, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	emp, ok := s.employees[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *emp
+	return &copied, nil
+}
+
+func (s *MemoryStore) Update(id int, emp *Employee) (*Employee, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.employees[id]; !ok {
+		return nil, ErrNotFound
+	}
+	emp.ID = id
+	stored := *emp
+	s.employees[id] = &stored
+	return &stored, nil
+}
+
+func (s *MemoryStore) Delete(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.employees[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.employees, id)
+	return nil
+}
+
+func (s *MemoryStore) List(offset, limit int, filter ListFilter) ([]*Employee, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	matched := make([]*Employee, 0, len(s.employees))
+	for _, emp := range s.employees {
+		if !matchesFilter(emp, filter) {
+			continue
+		}
+		copied := *emp
+		matched = append(matched, &copied)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	total := len(matched)
+	if limit == ListAll {
+		return matched, total, nil
+	}
+	if offset >= total {
+		return []*Employee{}, total, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return matched[offset:end], total, nil
+}
+
+func matchesFilter(emp *Employee, filter ListFilter) bool {
+	if filter.Name != "" && !strings.EqualFold(emp.Name, filter.Name) {
+		return false
+	}
+	if filter.Position != "" && !strings.EqualFold(emp.Position, filter.Position) {
+		return false
+	}
+	if filter.MinSalary != 0 && emp.Salary < filter.MinSalary {
+		return false
+	}
+	if filter.MaxSalary != 0 && emp.Salary > filter.MaxSalary {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SQLStore is a Store backed by database/sql. It has been exercised against
+// Postgres (driver "postgres", github.com/lib/pq) and SQLite (driver
+// "sqlite", modernc.org/sqlite); any database/sql driver that supports
+// AUTOINCREMENT/SERIAL primary keys should work.
+type SQLStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewSQLStore wraps an already-opened *sql.DB and creates the employees
+// table if it does not exist yet. driver is the database/sql driver name
+// ("postgres" or "sqlite") and only affects placeholder syntax.
+func NewSQLStore(db *sql.DB, driver string) (*SQLStore, error) {
+	s := &SQLStore{db: db, driver: driver}
+
+	var createTable string
+	switch driver {
+	case "postgres":
+		createTable = `CREATE TABLE IF NOT EXISTS employees (
+			id SERIAL PRIMARY KEY,
+			name TEXT NOT NULL,
+			position TEXT NOT NULL,
+			salary DOUBLE PRECISION NOT NULL
+		)`
+	default: // sqlite
+		createTable = `CREATE TABLE IF NOT EXISTS employees (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			position TEXT NOT NULL,
+			salary REAL NOT NULL
+		)`
+	}
+
+	if _, err := s.db.Exec(createTable); err != nil {
+		return nil, fmt.Errorf("creating employees table: %w", err)
+	}
+	return s, nil
+}
+
+// placeholder returns the n-th (1-indexed) bind placeholder for the store's
+// driver, e.g. "$1" for Postgres and "?" for SQLite.
+func (s *SQLStore) placeholder(n int) string {
+	if s.driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (s *SQLStore) Create(emp *Employee) (*Employee, error) {
+	query := fmt.Sprintf("INSERT INTO employees (name, position, salary) VALUES (%s, %s, %s)",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3))
+
+	if s.driver == "postgres" {
+		query += " RETURNING id"
+		if err := s.db.QueryRow(query, emp.Name, emp.Position, emp.Salary).Scan(&emp.ID); err != nil {
+			return nil, err
+		}
+		return emp, nil
+	}
+
+	res, err := s.db.Exec(query, emp.Name, emp.Position, emp.Salary)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	emp.ID = int(id)
+	return emp, nil
+}
+
+func (s *SQLStore) Get(id int) (*Employee, error) {
+	query := fmt.Sprintf("SELECT id, name, position, salary FROM employees WHERE id = %s", s.placeholder(1))
+
+	var emp Employee
+	err := s.db.QueryRow(query, id).Scan(&emp.ID, &emp.Name, &emp.Position, &emp.Salary)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &emp, nil
+}
+
+func (s *SQLStore) Update(id int, emp *Employee) (*Employee, error) {
+	query := fmt.Sprintf("UPDATE employees SET name = %s, position = %s, salary = %s WHERE id = %s",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4))
+
+	res, err := s.db.Exec(query, emp.Name, emp.Position, emp.Salary, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, ErrNotFound
+	}
+	emp.ID = id
+	return emp, nil
+}
+
+func (s *SQLStore) Delete(id int) error {
+	query := fmt.Sprintf("DELETE FROM employees WHERE id = %s", s.placeholder(1))
+
+	res, err := s.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLStore) List(offset, limit int, filter ListFilter) ([]*Employee, int, error) {
+	where := []string{}
+	args := []interface{}{}
+
+	if filter.Name != "" {
+		args = append(args, filter.Name)
+		where = append(where, fmt.Sprintf("LOWER(name) = LOWER(%s)", s.placeholder(len(args))))
+	}
+	if filter.Position != "" {
+		args = append(args, filter.Position)
+		where = append(where, fmt.Sprintf("LOWER(position) = LOWER(%s)", s.placeholder(len(args))))
+	}
+	if filter.MinSalary != 0 {
+		args = append(args, filter.MinSalary)
+		where = append(where, fmt.Sprintf("salary >= %s", s.placeholder(len(args))))
+	}
+	if filter.MaxSalary != 0 {
+		args = append(args, filter.MaxSalary)
+		where = append(where, fmt.Sprintf("salary <= %s", s.placeholder(len(args))))
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM employees" + whereClause
+	if err := s.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	listQuery := fmt.Sprintf("SELECT id, name, position, salary FROM employees%s ORDER BY id", whereClause)
+	listArgs := append([]interface{}{}, args...)
+	if limit != ListAll {
+		listQuery += fmt.Sprintf(" LIMIT %s OFFSET %s", s.placeholder(len(args)+1), s.placeholder(len(args)+2))
+		listArgs = append(listArgs, limit, offset)
+	}
+
+	rows, err := s.db.Query(listQuery, listArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	results := make([]*Employee, 0)
+	for rows.Next() {
+		var emp Employee
+		if err := rows.Scan(&emp.ID, &emp.Name, &emp.Position, &emp.Salary); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, &emp)
+	}
+	return results, total, rows.Err()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	maxNameLength     = 100
+	maxPositionLength = 100
+)
+
+// FieldError describes a single validation failure on one field of the
+// request body.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is the JSON body written for a 422 response, e.g.
+// {"code":"validation_error","fields":[{"field":"salary","message":"must be > 0"}]}.
+type ValidationError struct {
+	Code   string       `json:"code"`
+	Fields []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Code
+	}
+	return e.Code + ": " + e.Fields[0].Field + " " + e.Fields[0].Message
+}
+
+func (e *ValidationError) add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// validateEmployee applies the field-level rules shared by create and
+// update: Name and Position are required and bounded in length, Salary must
+// be positive. It returns nil when emp passes every rule.
+func validateEmployee(emp *Employee) *ValidationError {
+	verr := &ValidationError{Code: "validation_error"}
+
+	if emp.Name == "" {
+		verr.add("name", "is required")
+	} else if len(emp.Name) > maxNameLength {
+		verr.add("name", "must be at most 100 characters")
+	}
+
+	if emp.Position == "" {
+		verr.add("position", "is required")
+	} else if len(emp.Position) > maxPositionLength {
+		verr.add("position", "must be at most 100 characters")
+	}
+
+	if emp.Salary <= 0 {
+		verr.add("salary", "must be > 0")
+	}
+
+	if len(verr.Fields) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// writeValidationError writes verr as a 422 Unprocessable Entity JSON body.
+func writeValidationError(w http.ResponseWriter, verr *ValidationError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(verr)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestValidateEmployee(t *testing.T) {
+	testCases := []struct {
+		name      string
+		emp       Employee
+		wantField string
+	}{
+		{"missing name", Employee{Position: "Engineer", Salary: 1000}, "name"},
+		{"missing position", Employee{Name: "Alice", Salary: 1000}, "position"},
+		{"zero salary", Employee{Name: "Alice", Position: "Engineer", Salary: 0}, "salary"},
+		{"negative salary", Employee{Name: "Alice", Position: "Engineer", Salary: -1}, "salary"},
+		{"name too long", Employee{Name: string(make([]byte, maxNameLength+1)), Position: "Engineer", Salary: 1000}, "name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verr := validateEmployee(&tc.emp)
+			if verr == nil {
+				t.Fatalf("expected a validation error, got none")
+			}
+			found := false
+			for _, f := range verr.Fields {
+				if f.Field == tc.wantField {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected a failure on field %q, got %+v", tc.wantField, verr.Fields)
+			}
+		})
+	}
+
+	if verr := validateEmployee(&Employee{Name: "Alice", Position: "Engineer", Salary: 1000}); verr != nil {
+		t.Errorf("expected valid employee to pass, got %+v", verr)
+	}
+}
+
+func TestCreateEmployeeValidation(t *testing.T) {
+	store = NewMemoryStore()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/employees", CreateEmployee).Methods("POST")
+	router.Use(loggingMiddleware)
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	badEmp := Employee{Name: "", Position: "Engineer", Salary: -5}
+	body, _ := json.Marshal(badEmp)
+
+	req, err := http.NewRequest("POST", ts.URL+"/employees", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %v, got %v", http.StatusUnprocessableEntity, resp.StatusCode)
+	}
+
+	var verr ValidationError
+	if err := json.NewDecoder(resp.Body).Decode(&verr); err != nil {
+		t.Fatal("Failed to unmarshal response:", err)
+	}
+	if verr.Code != "validation_error" {
+		t.Errorf("Expected code validation_error, got %q", verr.Code)
+	}
+	if len(verr.Fields) != 2 {
+		t.Errorf("Expected 2 field errors (name, salary), got %+v", verr.Fields)
+	}
+}
+
+func TestUpdateEmployeeValidation(t *testing.T) {
+	store = NewMemoryStore()
+
+	existing := Employee{Name: "John Doe", Position: "Manager", Salary: 120000}
+	CreateEmployeeHelper(t, &existing)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/employees/{id}", UpdateEmployee).Methods("PUT")
+	router.Use(loggingMiddleware)
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	badEmp := Employee{Name: "John Doe", Position: "", Salary: 120000}
+	body, _ := json.Marshal(badEmp)
+
+	req, err := http.NewRequest("PUT", ts.URL+fmt.Sprintf("/employees/%d", existing.ID), bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %v, got %v", http.StatusUnprocessableEntity, resp.StatusCode)
+	}
+}